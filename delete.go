@@ -0,0 +1,77 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// ensureRemoveAll deletes path the same way os.RemoveAll does, but retries
+// past transient failures instead of giving up on the first error:
+//
+//   - EBUSY / sharing-violation errors (an editor or IDE holding a file open,
+//     a virus scanner, a slow unmount) are retried with exponential backoff
+//     up to retries times.
+//   - EACCES / EPERM errors (a subdirectory missing write/execute bits) are
+//     handled by chmod'ing every directory under path to 0700 and retrying
+//     once.
+//   - On Windows, the chmod fallback additionally clears the read-only
+//     attribute on every file and directory under path.
+func ensureRemoveAll(path string, retries int) error {
+	err := os.RemoveAll(path)
+	if err == nil {
+		return nil
+	}
+
+	if isBusyErr(err) {
+		delay := 50 * time.Millisecond
+		for attempt := 0; attempt < retries && isBusyErr(err); attempt++ {
+			time.Sleep(delay)
+			delay *= 2
+			err = os.RemoveAll(path)
+		}
+		if err == nil {
+			return nil
+		}
+	}
+
+	if errors.Is(err, fs.ErrPermission) {
+		if chmodErr := makeTreeWritable(path); chmodErr == nil {
+			if err2 := os.RemoveAll(path); err2 == nil {
+				return nil
+			} else {
+				err = err2
+			}
+		}
+	}
+
+	return fmt.Errorf("ensureRemoveAll %s: %w", path, err)
+}
+
+// isBusyErr reports whether err looks like a transient "in use" failure
+// (EBUSY on Unix, a sharing violation on Windows) worth retrying.
+func isBusyErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	return errors.Is(err, syscall.EBUSY) || isWindowsSharingViolation(err)
+}
+
+// makeTreeWritable walks every directory under path, chmod'ing it to 0700 so
+// a subsequent RemoveAll can recurse into and unlink its contents. On Windows
+// it also clears the read-only attribute on files and directories.
+func makeTreeWritable(path string) error {
+	return filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil // best effort
+		}
+		if d.IsDir() {
+			_ = os.Chmod(p, 0700)
+		}
+		return clearReadOnly(p)
+	})
+}
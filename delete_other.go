@@ -0,0 +1,15 @@
+//go:build !windows
+
+package main
+
+// isWindowsSharingViolation is always false outside Windows; EBUSY is the
+// Unix equivalent and is handled separately in ensureRemoveAll.
+func isWindowsSharingViolation(err error) bool {
+	return false
+}
+
+// clearReadOnly is a no-op outside Windows; the chmod in makeTreeWritable
+// already restores write permission.
+func clearReadOnly(p string) error {
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// isWindowsSharingViolation reports whether err is the Windows
+// ERROR_SHARING_VIOLATION that occurs when another process (an editor, an
+// IDE, a virus scanner) still has a file open.
+func isWindowsSharingViolation(err error) bool {
+	const errorSharingViolation = 32
+	if pe, ok := err.(*os.PathError); ok {
+		if errno, ok := pe.Err.(interface{ Errno() uintptr }); ok {
+			return errno.Errno() == errorSharingViolation
+		}
+	}
+	return false
+}
+
+// clearReadOnly removes the read-only attribute from p, if set, so it can be
+// deleted or have its contents unlinked.
+func clearReadOnly(p string) error {
+	info, err := os.Lstat(p)
+	if err != nil {
+		return nil
+	}
+	if info.Mode()&0200 == 0 {
+		return os.Chmod(p, info.Mode()|0200)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// parseDuration parses a duration string for CLI flags like -older-than=30d
+// or -unused-since=12h. It extends time.ParseDuration with a "d" (day) unit,
+// since "30d" reads far more naturally than "720h" in a flag value.
+func parseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasSuffix(s, "d") {
+		days, err := strconv.ParseFloat(strings.TrimSuffix(s, "d"), 64)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(days * 24 * float64(time.Hour)), nil
+	}
+	return time.ParseDuration(s)
+}
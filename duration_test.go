@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDurationDays(t *testing.T) {
+	got, err := parseDuration("30d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 30 * 24 * time.Hour; got != want {
+		t.Errorf("parseDuration(30d) = %v, want %v", got, want)
+	}
+}
+
+func TestParseDurationFractionalDays(t *testing.T) {
+	got, err := parseDuration("1.5d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 36 * time.Hour; got != want {
+		t.Errorf("parseDuration(1.5d) = %v, want %v", got, want)
+	}
+}
+
+func TestParseDurationStdlibUnits(t *testing.T) {
+	got, err := parseDuration("12h")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := 12 * time.Hour; got != want {
+		t.Errorf("parseDuration(12h) = %v, want %v", got, want)
+	}
+}
+
+func TestParseDurationInvalid(t *testing.T) {
+	if _, err := parseDuration("nope"); err == nil {
+		t.Error("expected an error for a garbage duration string")
+	}
+	if _, err := parseDuration("xd"); err == nil {
+		t.Error("expected an error for a non-numeric day count")
+	}
+}
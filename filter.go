@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// usageSiblings are files commonly found next to a dependency directory
+// (node_modules, vendor, ...) whose mtime hints at when the project was last
+// touched, without having to walk the (possibly huge) directory itself.
+var usageSiblings = []string{
+	"package.json", "package-lock.json", "go.sum", "go.mod",
+	"Cargo.lock", "Cargo.toml", "requirements.txt", "Pipfile.lock",
+	"build.gradle", "pom.xml",
+}
+
+// estimateLastUsed approximates when a project was last worked on by peeking
+// at the directory's own mtime and a handful of well-known sibling
+// manifest/lockfiles in its parent, rather than walking the full tree.
+func estimateLastUsed(dir foundDir) time.Time {
+	newest := dir.modTime
+	parent := filepath.Dir(dir.path)
+	for _, name := range usageSiblings {
+		if info, err := os.Stat(filepath.Join(parent, name)); err == nil {
+			if info.ModTime().After(newest) {
+				newest = info.ModTime()
+			}
+		}
+	}
+	return newest
+}
+
+// filterFound applies the -older-than/-larger-than/-unused-since flags to
+// found, returning only the entries that pass all configured filters.
+// Filtered-out entries are reported (with their reason) through r when
+// -v is set, but otherwise silently excluded from the summary.
+func filterFound(found []foundDir, r reporter) []foundDir {
+	if olderThanFlag == "" && largerThanFlag == "" && unusedSinceFlag == "" {
+		return found
+	}
+
+	var olderThan, unusedSince time.Duration
+	var largerThan int64
+	var err error
+
+	if olderThanFlag != "" {
+		if olderThan, err = parseDuration(olderThanFlag); err != nil {
+			r.Infof("⚠️  Invalid -older-than value %q: %v\n", olderThanFlag, err)
+			olderThanFlag = ""
+		}
+	}
+	if largerThanFlag != "" {
+		if largerThan, err = parseSize(largerThanFlag); err != nil {
+			r.Infof("⚠️  Invalid -larger-than value %q: %v\n", largerThanFlag, err)
+			largerThanFlag = ""
+		}
+	}
+	if unusedSinceFlag != "" {
+		if unusedSince, err = parseDuration(unusedSinceFlag); err != nil {
+			r.Infof("⚠️  Invalid -unused-since value %q: %v\n", unusedSinceFlag, err)
+			unusedSinceFlag = ""
+		}
+	}
+
+	kept := make([]foundDir, 0, len(found))
+	for _, f := range found {
+		if olderThanFlag != "" && time.Since(f.modTime) < olderThan {
+			if verbose {
+				r.Infof("⏭️  Skipped: %s (not older than %s)\n", f.path, olderThanFlag)
+			}
+			continue
+		}
+		if largerThanFlag != "" && f.size < largerThan {
+			if verbose {
+				r.Infof("⏭️  Skipped: %s (smaller than %s)\n", f.path, largerThanFlag)
+			}
+			continue
+		}
+		if unusedSinceFlag != "" && time.Since(estimateLastUsed(f)) < unusedSince {
+			if verbose {
+				r.Infof("⏭️  Skipped: %s (used more recently than %s)\n", f.path, unusedSinceFlag)
+			}
+			continue
+		}
+		kept = append(kept, f)
+	}
+	return kept
+}
@@ -6,15 +6,30 @@ import (
 	"log"
 	"os"
 	"path/filepath"
-	"strings"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var (
-	rootPath   string
-	dryRun     bool
-	skipPrompt bool
-	verbose    bool
+	rootPath        string
+	dryRun          bool
+	skipPrompt      bool
+	verbose         bool
+	workers         int
+	walkerName      string
+	retries         int
+	targets         string
+	preset          string
+	exclude         string
+	followSymlinks  bool
+	format          string
+	interactive     bool
+	trash           bool
+	olderThanFlag   string
+	largerThanFlag  string
+	unusedSinceFlag string
 )
 
 func init() {
@@ -22,10 +37,55 @@ func init() {
 	flag.BoolVar(&dryRun, "dry-run", false, "Only list directories, don't delete")
 	flag.BoolVar(&skipPrompt, "y", false, "Skip confirmation prompt")
 	flag.BoolVar(&verbose, "v", false, "Verbose output")
-	flag.Parse()
+	flag.IntVar(&workers, "workers", runtime.NumCPU(), "Number of concurrent workers for sizing and deletion")
+	flag.StringVar(&walkerName, "walker", "stdlib", "Directory-walk backend to use: stdlib or godirwalk")
+	flag.IntVar(&retries, "retry", 3, "Number of retries with exponential backoff for EBUSY/locked deletions")
+	flag.StringVar(&targets, "targets", "node_modules,vendor", "Comma-separated names/glob patterns of directories to find")
+	flag.StringVar(&preset, "preset", "", "Comma-separated language-ecosystem presets to add to -targets (node,go,rust,python,java,dotnet,swift,all)")
+	flag.StringVar(&exclude, "exclude", "", "Comma-separated names/glob patterns of directories to skip entirely (e.g. .git)")
+	flag.BoolVar(&followSymlinks, "follow-symlinks", false, "Let symlinked directories match/be sized by what they point to (off by default to avoid cycles); deletion still only removes the symlink itself, never its target's contents")
+	flag.StringVar(&format, "format", "text", "Output format: text, json, or ndjson")
+	flag.BoolVar(&interactive, "interactive", false, "Open an interactive TUI to pick which found directories to delete")
+	flag.BoolVar(&trash, "trash", false, "Move matched directories to a quarantine area instead of deleting them, see `delPack list-trash`")
+	flag.StringVar(&olderThanFlag, "older-than", "", "Only include directories whose mtime is older than this (e.g. 30d)")
+	flag.StringVar(&largerThanFlag, "larger-than", "", "Only include directories at least this large (e.g. 100MiB)")
+	flag.StringVar(&unusedSinceFlag, "unused-since", "", "Only include directories whose project looks untouched since this duration (e.g. 90d)")
+}
+
+// foundDir is a directory matched during the scan, along with its computed
+// size and the target name/pattern that matched it.
+type foundDir struct {
+	path    string
+	size    int64
+	kind    string
+	modTime time.Time
 }
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "list-trash":
+			cmdListTrash(os.Args[2:])
+			return
+		case "restore":
+			cmdRestore(os.Args[2:])
+			return
+		case "empty-trash":
+			cmdEmptyTrash(os.Args[2:])
+			return
+		}
+	}
+	flag.Parse()
+	targetsExplicit := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "targets" {
+			targetsExplicit = true
+		}
+	})
+	runScanAndDelete(targetsExplicit)
+}
+
+func runScanAndDelete(targetsExplicit bool) {
 	startTime := time.Now()
 	absRoot, err := filepath.Abs(rootPath)
 	if err != nil {
@@ -37,135 +97,235 @@ func main() {
 		log.Fatalf("Path does not exist: %s", absRoot)
 	}
 
-	fmt.Printf("🔍 Searching for node_modules and vendor directories in: %s\n", absRoot)
+	if workers < 1 {
+		workers = 1
+	}
+
+	r := newReporter(format)
+	targetMatcher := buildTargetMatcher(targets, targetsExplicit, preset)
+	excludeMatcher := newMatcher(exclude)
+
+	r.Infof("🔍 Searching for %s directories in: %s\n", targetMatcher.String(), absRoot)
 	if dryRun {
-		fmt.Println("📋 DRY RUN MODE: No directories will be deleted.")
+		r.Infof("📋 DRY RUN MODE: No directories will be deleted.\n")
 	}
 	if verbose {
-		fmt.Println("📢 Verbose mode enabled")
+		r.Infof("📢 Verbose mode enabled\n")
+		r.Infof("🧵 Using %d worker(s)\n", workers)
+	}
+
+	found, scanErrors, err := scan(absRoot, r, targetMatcher, excludeMatcher)
+	if err != nil {
+		log.Fatalf("❌ Error walking directory: %v", err)
+	}
+
+	if len(scanErrors) > 0 && verbose {
+		r.Infof("\n📋 Scan Errors:\n")
+		for _, e := range scanErrors {
+			r.ScanErrorMsg(e)
+		}
 	}
 
+	found = filterFound(found, r)
+
 	var totalSize int64
-	var dirsToDelete []string
-	var dirSizes []int64
-	var scanErrors []string
+	for _, f := range found {
+		totalSize += f.size
+	}
+	r.ScanSummary(absRoot, len(found), totalSize, time.Since(startTime))
+
+	if len(found) == 0 {
+		r.Infof("✅ No matching directories found.\n")
+		r.Flush()
+		return
+	}
+
+	if dryRun {
+		r.Infof("🏁 Dry run completed successfully.\n")
+		r.Flush()
+		return
+	}
 
-	err = filepath.WalkDir(absRoot, func(path string, d os.DirEntry, err error) error {
+	selected := found
+	if interactive {
+		picked, ok, err := runInteractiveSelect(found)
 		if err != nil {
-			if verbose {
-				scanErrors = append(scanErrors, fmt.Sprintf("⚠️  Error accessing %s: %v", path, err))
-			}
-			return nil // Skip errors and continue
+			log.Fatalf("❌ Interactive selection failed: %v", err)
+		}
+		if !ok || len(picked) == 0 {
+			r.Infof("🛑 Operation cancelled by user.\n")
+			r.Flush()
+			return
 		}
+		selected = picked
+	} else if !r.Confirm(skipPrompt) {
+		r.Infof("🛑 Operation cancelled by user.\n")
+		r.Flush()
+		return
+	}
 
-		if !d.IsDir() {
-			return nil
+	r.Infof("\n🗑️  Starting deletion process...\n")
+	deletedCount, deletedSize, deleteErrors := deleteAll(selected, r)
+
+	if len(deleteErrors) > 0 {
+		r.Infof("\n⚠️  Deletion Errors:\n")
+		for _, e := range deleteErrors {
+			r.ScanErrorMsg(e)
 		}
+	}
 
-		name := d.Name()
-		if name == "node_modules" || name == "vendor" {
-			// Calculate directory size
-			if verbose {
-				fmt.Printf("📊 Calculating size for: %s\n", path)
-			}
+	r.DeletionSummary(deletedCount, len(selected), deletedSize, time.Since(startTime))
+	r.Flush()
+}
 
-			size, err := dirSize(path)
+// scan walks absRoot looking for directories matching -targets/-preset and
+// computes their sizes concurrently using a pool of `workers` goroutines. The
+// walk itself stays single-threaded (the walker interface can't safely be
+// parallelized over one tree), but the expensive per-directory size
+// calculation fans out.
+func scan(absRoot string, r reporter, targetMatcher, excludeMatcher matcher) ([]foundDir, []string, error) {
+	pathsCh := make(chan string, workers)
+	w := newWalker(walkerName)
+
+	var walkErr error
+	go func() {
+		defer close(pathsCh)
+		walkErr = w.Walk(absRoot, func(path string, name string, isDir bool, isSymlink bool, err error) error {
 			if err != nil {
 				if verbose {
-					fmt.Printf("⚠️  Could not calculate size of %s: %v\n", path, err)
+					r.Infof("⚠️  Error accessing %s: %v\n", path, err)
 				}
-				size = 0
+				return nil // Skip errors and continue
 			}
 
-			totalSize += size
-			dirsToDelete = append(dirsToDelete, path)
-			dirSizes = append(dirSizes, size)
+			if isSymlink && !followSymlinks {
+				return nil // don't descend into or match symlinked directories
+			}
 
-			fmt.Printf("📁 Found: %s (%s)\n", path, formatBytes(size))
+			if !isDir {
+				return nil
+			}
 
-			// Skip walking inside this directory to save time
-			return filepath.SkipDir
-		}
-		return nil
-	})
+			if excludeMatcher.Match(name) {
+				if verbose {
+					r.Infof("⏭️  Excluding: %s\n", path)
+				}
+				return filepath.SkipDir
+			}
 
-	if err != nil {
-		log.Fatalf("❌ Error walking directory: %v", err)
-	}
+			if targetMatcher.Match(name) {
+				pathsCh <- path
+				// Skip walking inside this directory to save time
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	}()
 
-	// Report any scan errors
-	if len(scanErrors) > 0 && verbose {
-		fmt.Println("\n📋 Scan Errors:")
-		for _, err := range scanErrors {
-			fmt.Println(err)
-		}
-	}
+	var (
+		mu    sync.Mutex
+		found []foundDir
+		errs  []string
+		wg    sync.WaitGroup
+	)
 
-	if len(dirsToDelete) == 0 {
-		fmt.Println("✅ No node_modules or vendor directories found.")
-		return
-	}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range pathsCh {
+				if verbose {
+					r.Infof("📊 Calculating size for: %s\n", path)
+				}
 
-	fmt.Printf("\n📊 Summary:\n")
-	fmt.Printf("   • Directories found: %d\n", len(dirsToDelete))
-	fmt.Printf("   • Total size: %s\n", formatBytes(totalSize))
-	fmt.Printf("   • Scan duration: %v\n", time.Since(startTime))
+				size, err := dirSize(path)
+				if err != nil {
+					size = 0
+					if verbose {
+						mu.Lock()
+						errs = append(errs, fmt.Sprintf("Could not calculate size of %s: %v", path, err))
+						mu.Unlock()
+					}
+				}
 
-	if dryRun {
-		fmt.Println("🏁 Dry run completed successfully.")
-		return
-	}
+				kind := filepath.Base(path)
+				var modTime time.Time
+				if info, err := os.Stat(path); err == nil {
+					modTime = info.ModTime()
+				}
+				r.Found(path, kind, size)
 
-	if !skipPrompt {
-		fmt.Print("\n⚠️  Are you sure you want to delete these directories? (y/N): ")
-		var response string
-		fmt.Scanln(&response)
-		if strings.ToLower(response) != "y" {
-			fmt.Println("🛑 Operation cancelled by user.")
-			return
-		}
+				mu.Lock()
+				found = append(found, foundDir{path: path, size: size, kind: kind, modTime: modTime})
+				mu.Unlock()
+			}
+		}()
 	}
+	wg.Wait()
 
-	fmt.Println("\n🗑️  Starting deletion process...")
-	var deletedSize int64
-	var deletedCount int
-	var deleteErrors []string
-
-	for i, dir := range dirsToDelete {
-		fmt.Printf("🗑︸  Deleting: %s ... ", dir)
-		err := os.RemoveAll(dir)
-		if err != nil {
-			errorMsg := fmt.Sprintf("❌ ERROR: %v", err)
-			fmt.Println(errorMsg)
-			deleteErrors = append(deleteErrors, fmt.Sprintf("%s: %v", dir, err))
-		} else {
-			fmt.Println("✅ Done.")
-			deletedCount++
-			deletedSize += dirSizes[i]
-		}
-	}
+	return found, errs, walkErr
+}
 
-	// Report deletion errors if any
-	if len(deleteErrors) > 0 {
-		fmt.Println("\n⚠️  Deletion Errors:")
-		for _, err := range deleteErrors {
-			fmt.Println(err)
+// deleteAll removes every directory in found concurrently using the
+// configured worker pool, returning the count and total size of successful
+// deletions along with any per-directory errors.
+func deleteAll(found []foundDir, r reporter) (int, int64, []string) {
+	dirsCh := make(chan foundDir, workers)
+	go func() {
+		defer close(dirsCh)
+		for _, f := range found {
+			dirsCh <- f
 		}
-	}
+	}()
 
-	fmt.Printf("\n📊 Deletion Results:\n")
-	fmt.Printf("   • Successfully deleted: %d out of %d directories\n", deletedCount, len(dirsToDelete))
-	fmt.Printf("   • Freed space: %s\n", formatBytes(deletedSize))
-	fmt.Printf("   • Total operation time: %v\n", time.Since(startTime))
+	var (
+		deletedCount int64
+		deletedSize  int64
+		mu           sync.Mutex
+		deleteErrors []string
+		wg           sync.WaitGroup
+	)
 
-	if deletedCount > 0 {
-		fmt.Println("🎉 Operation completed successfully!")
-	} else {
-		fmt.Println("❌ No directories were deleted.")
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for f := range dirsCh {
+				r.Deleting(f.path)
+				var err error
+				if trash {
+					_, err = moveToTrash(f.path, f.size)
+				} else {
+					err = ensureRemoveAll(f.path, retries)
+				}
+				r.Deleted(f.path, f.size, err)
+				if err != nil {
+					mu.Lock()
+					deleteErrors = append(deleteErrors, fmt.Sprintf("%s: %v", f.path, err))
+					mu.Unlock()
+				} else {
+					atomic.AddInt64(&deletedCount, 1)
+					atomic.AddInt64(&deletedSize, f.size)
+				}
+			}
+		}()
 	}
+	wg.Wait()
+
+	return int(deletedCount), deletedSize, deleteErrors
 }
 
 func dirSize(path string) (int64, error) {
+	// filepath.Walk lstats its root: if path is itself a symlink (possible
+	// with -follow-symlinks), it would report the symlink's own size instead
+	// of recursing into what it points to. Resolve it first so a followed
+	// symlinked directory gets sized like any other match.
+	if info, err := os.Lstat(path); err == nil && info.Mode()&os.ModeSymlink != 0 {
+		if target, err := filepath.EvalSymlinks(path); err == nil {
+			path = target
+		}
+	}
+
 	var size int64
 	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
 		if err != nil {
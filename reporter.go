@@ -0,0 +1,255 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reporter abstracts every user-facing output delPack produces, so the
+// human-readable text path and the machine-readable json/ndjson paths can't
+// diverge: all three are driven from the same call sites in main/scan/deleteAll.
+type reporter interface {
+	// Infof prints a free-form human chatter line; a no-op for json/ndjson.
+	Infof(format string, args ...interface{})
+	// Found records a matched directory.
+	Found(path, kind string, size int64)
+	// ScanErrorMsg records a non-fatal error encountered while scanning.
+	ScanErrorMsg(msg string)
+	// ScanSummary records (and, depending on format, prints) the completed scan.
+	ScanSummary(root string, count int, totalSize int64, duration time.Duration)
+	// Confirm reports whether deletion should proceed. Text mode prompts
+	// interactively unless skipPrompt is set; machine-readable modes require
+	// an explicit skipPrompt (-y) since there is no human to prompt.
+	Confirm(skipPrompt bool) bool
+	// Deleting announces that path is about to be deleted.
+	Deleting(path string)
+	// Deleted records the outcome of deleting path.
+	Deleted(path string, size int64, err error)
+	// DeletionSummary records (and, depending on format, prints) the completed deletion.
+	DeletionSummary(deletedCount, totalCount int, deletedSize int64, duration time.Duration)
+	// Flush emits any output that was buffered until the run completed.
+	Flush()
+}
+
+// newReporter returns the reporter implementation named by -format,
+// defaulting to the text reporter for any unrecognized value.
+func newReporter(format string) reporter {
+	switch format {
+	case "json":
+		return &jsonReporter{}
+	case "ndjson":
+		return &ndjsonReporter{}
+	default:
+		return &textReporter{}
+	}
+}
+
+// textReporter reproduces delPack's original emoji-laden, human-readable output.
+type textReporter struct{}
+
+func (textReporter) Infof(format string, args ...interface{}) {
+	fmt.Printf(format, args...)
+}
+
+func (textReporter) Found(path, kind string, size int64) {
+	fmt.Printf("📁 Found: %s (%s)\n", path, formatBytes(size))
+}
+
+func (textReporter) ScanErrorMsg(msg string) {
+	fmt.Printf("⚠️  %s\n", msg)
+}
+
+func (textReporter) ScanSummary(root string, count int, totalSize int64, duration time.Duration) {
+	fmt.Printf("\n📊 Summary:\n")
+	fmt.Printf("   • Directories found: %d\n", count)
+	fmt.Printf("   • Total size: %s\n", formatBytes(totalSize))
+	fmt.Printf("   • Scan duration: %v\n", duration)
+}
+
+func (textReporter) Confirm(skipPrompt bool) bool {
+	if skipPrompt {
+		return true
+	}
+	fmt.Print("\n⚠️  Are you sure you want to delete these directories? (y/N): ")
+	var response string
+	fmt.Scanln(&response)
+	return strings.ToLower(response) == "y"
+}
+
+func (textReporter) Deleting(path string) {
+	fmt.Printf("🗑️  Deleting: %s ... ", path)
+}
+
+func (textReporter) Deleted(path string, size int64, err error) {
+	if err != nil {
+		fmt.Printf("❌ ERROR: %v\n", err)
+		return
+	}
+	fmt.Println("✅ Done.")
+}
+
+func (textReporter) DeletionSummary(deletedCount, totalCount int, deletedSize int64, duration time.Duration) {
+	fmt.Printf("\n📊 Deletion Results:\n")
+	fmt.Printf("   • Successfully deleted: %d out of %d directories\n", deletedCount, totalCount)
+	fmt.Printf("   • Freed space: %s\n", formatBytes(deletedSize))
+	fmt.Printf("   • Total operation time: %v\n", duration)
+	if deletedCount > 0 {
+		fmt.Println("🎉 Operation completed successfully!")
+	} else {
+		fmt.Println("❌ No directories were deleted.")
+	}
+}
+
+func (textReporter) Flush() {}
+
+// jsonEntry is a single scanned directory in the json format's document.
+type jsonEntry struct {
+	Path      string `json:"path"`
+	SizeBytes int64  `json:"size_bytes"`
+	Kind      string `json:"kind"`
+}
+
+// jsonDeletion is a single deletion outcome in the json format's document.
+type jsonDeletion struct {
+	Path    string `json:"path"`
+	Deleted bool   `json:"deleted"`
+	Error   string `json:"error,omitempty"`
+}
+
+// jsonDocument is the single structured document printed by the json format.
+type jsonDocument struct {
+	Root       string         `json:"root"`
+	ScannedAt  string         `json:"scanned_at"`
+	DurationMs int64          `json:"duration_ms"`
+	Entries    []jsonEntry    `json:"entries"`
+	Totals     jsonTotals     `json:"totals"`
+	Deletions  []jsonDeletion `json:"deletions,omitempty"`
+}
+
+type jsonTotals struct {
+	Count     int   `json:"count"`
+	SizeBytes int64 `json:"size_bytes"`
+}
+
+// jsonReporter buffers the whole run and prints one document on Flush.
+type jsonReporter struct {
+	mu  sync.Mutex
+	doc jsonDocument
+}
+
+func (*jsonReporter) Infof(string, ...interface{}) {}
+
+func (r *jsonReporter) Found(path, kind string, size int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.doc.Entries = append(r.doc.Entries, jsonEntry{Path: path, SizeBytes: size, Kind: kind})
+}
+
+func (*jsonReporter) ScanErrorMsg(string) {}
+
+func (r *jsonReporter) ScanSummary(root string, count int, totalSize int64, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.doc.Root = root
+	r.doc.ScannedAt = time.Now().Format(time.RFC3339)
+	r.doc.DurationMs = duration.Milliseconds()
+	r.doc.Totals = jsonTotals{Count: count, SizeBytes: totalSize}
+}
+
+func (*jsonReporter) Confirm(skipPrompt bool) bool { return skipPrompt }
+
+func (*jsonReporter) Deleting(string) {}
+
+func (r *jsonReporter) Deleted(path string, size int64, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	d := jsonDeletion{Path: path, Deleted: err == nil}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	r.doc.Deletions = append(r.doc.Deletions, d)
+}
+
+func (*jsonReporter) DeletionSummary(int, int, int64, time.Duration) {}
+
+func (r *jsonReporter) Flush() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(r.doc)
+}
+
+// ndjsonReporter streams one JSON object per event as it happens, so CI
+// pipelines and editors can consume delPack incrementally instead of
+// waiting for the whole run to finish.
+type ndjsonReporter struct {
+	mu sync.Mutex
+}
+
+func (r *ndjsonReporter) emit(v interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	fmt.Println(string(b))
+}
+
+func (*ndjsonReporter) Infof(string, ...interface{}) {}
+
+func (r *ndjsonReporter) Found(path, kind string, size int64) {
+	r.emit(struct {
+		Type      string `json:"type"`
+		Path      string `json:"path"`
+		SizeBytes int64  `json:"size_bytes"`
+		Kind      string `json:"kind"`
+	}{"found", path, size, kind})
+}
+
+func (*ndjsonReporter) ScanErrorMsg(string) {}
+
+func (r *ndjsonReporter) ScanSummary(root string, count int, totalSize int64, duration time.Duration) {
+	r.emit(struct {
+		Type       string `json:"type"`
+		Root       string `json:"root"`
+		Count      int    `json:"count"`
+		SizeBytes  int64  `json:"size_bytes"`
+		DurationMs int64  `json:"duration_ms"`
+	}{"scan_summary", root, count, totalSize, duration.Milliseconds()})
+}
+
+func (*ndjsonReporter) Confirm(skipPrompt bool) bool { return skipPrompt }
+
+func (*ndjsonReporter) Deleting(string) {}
+
+func (r *ndjsonReporter) Deleted(path string, size int64, err error) {
+	d := struct {
+		Type      string `json:"type"`
+		Path      string `json:"path"`
+		SizeBytes int64  `json:"size_bytes"`
+		Deleted   bool   `json:"deleted"`
+		Error     string `json:"error,omitempty"`
+	}{"deletion", path, size, err == nil, ""}
+	if err != nil {
+		d.Error = err.Error()
+	}
+	r.emit(d)
+}
+
+func (r *ndjsonReporter) DeletionSummary(deletedCount, totalCount int, deletedSize int64, duration time.Duration) {
+	r.emit(struct {
+		Type         string `json:"type"`
+		DeletedCount int    `json:"deleted_count"`
+		TotalCount   int    `json:"total_count"`
+		SizeBytes    int64  `json:"size_bytes"`
+		DurationMs   int64  `json:"duration_ms"`
+	}{"deletion_summary", deletedCount, totalCount, deletedSize, duration.Milliseconds()})
+}
+
+func (*ndjsonReporter) Flush() {}
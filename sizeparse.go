@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeUnits maps the suffixes accepted by -larger-than to their byte
+// multiplier, matching the binary units formatBytes prints.
+var sizeUnits = map[string]int64{
+	"b":   1,
+	"kib": 1 << 10,
+	"mib": 1 << 20,
+	"gib": 1 << 30,
+	"tib": 1 << 40,
+	"pib": 1 << 50,
+}
+
+// parseSize parses a human size string like "100MiB" or "2GiB" into bytes.
+// A bare number (no unit) is interpreted as bytes.
+func parseSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	i := len(s)
+	for i > 0 && (s[i-1] < '0' || s[i-1] > '9') && s[i-1] != '.' {
+		i--
+	}
+	numPart, unitPart := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	if unitPart == "" {
+		unitPart = "b"
+	}
+
+	mult, ok := sizeUnits[unitPart]
+	if !ok {
+		return 0, fmt.Errorf("unknown size unit %q", unitPart)
+	}
+
+	n, err := strconv.ParseFloat(numPart, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+	return int64(n * float64(mult)), nil
+}
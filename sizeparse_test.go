@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestParseSizeBinaryUnits(t *testing.T) {
+	cases := map[string]int64{
+		"100MiB": 100 * (1 << 20),
+		"2GiB":   2 * (1 << 30),
+		"512KiB": 512 * (1 << 10),
+		"1B":     1,
+	}
+	for in, want := range cases {
+		got, err := parseSize(in)
+		if err != nil {
+			t.Fatalf("parseSize(%q) returned error: %v", in, err)
+		}
+		if got != want {
+			t.Errorf("parseSize(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
+
+func TestParseSizeBareNumberIsBytes(t *testing.T) {
+	got, err := parseSize("1024")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 1024 {
+		t.Errorf("parseSize(1024) = %d, want 1024", got)
+	}
+}
+
+func TestParseSizeFractional(t *testing.T) {
+	got, err := parseSize("1.5MiB")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := int64(1.5 * (1 << 20)); got != want {
+		t.Errorf("parseSize(1.5MiB) = %d, want %d", got, want)
+	}
+}
+
+func TestParseSizeUnknownUnit(t *testing.T) {
+	if _, err := parseSize("100XB"); err == nil {
+		t.Error("expected an error for an unknown unit")
+	}
+}
+
+func TestParseSizeEmpty(t *testing.T) {
+	if _, err := parseSize(""); err == nil {
+		t.Error("expected an error for an empty size string")
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// presets maps a short ecosystem name to the directory names/patterns it
+// typically leaves behind. -preset=all expands to the union of every preset.
+var presets = map[string][]string{
+	"node":   {"node_modules"},
+	"go":     {"vendor"},
+	"rust":   {"target"},
+	"python": {"__pycache__", ".venv", "venv", "*.egg-info"},
+	"java":   {"build", ".gradle", "target"},
+	"dotnet": {"bin", "obj"},
+	"swift":  {".build"},
+}
+
+// matcher tests a directory's base name against a set of exact names and
+// glob patterns (as understood by path.Match).
+type matcher struct {
+	names    map[string]bool
+	patterns []string
+}
+
+// newMatcher builds a matcher from a comma-separated list of names/patterns.
+// Entries containing a glob metacharacter are treated as patterns; everything
+// else is compared for an exact match.
+func newMatcher(list string) matcher {
+	m := matcher{names: map[string]bool{}}
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.ContainsAny(entry, "*?[") {
+			m.patterns = append(m.patterns, entry)
+		} else {
+			m.names[entry] = true
+		}
+	}
+	return m
+}
+
+// Match reports whether name matches the matcher's exact names or patterns.
+func (m matcher) Match(name string) bool {
+	if m.names[name] {
+		return true
+	}
+	for _, p := range m.patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// add inserts a single name/pattern into the matcher.
+func (m *matcher) add(entry string) {
+	if strings.ContainsAny(entry, "*?[") {
+		m.patterns = append(m.patterns, entry)
+	} else {
+		m.names[entry] = true
+	}
+}
+
+// String lists everything the matcher matches, names first then patterns,
+// both sorted, for display purposes (e.g. the scan's opening banner).
+func (m matcher) String() string {
+	names := make([]string, 0, len(m.names))
+	for n := range m.names {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	patterns := append([]string(nil), m.patterns...)
+	sort.Strings(patterns)
+	return strings.Join(append(names, patterns...), ",")
+}
+
+// expandPresets turns a comma-separated list of preset names (or "all") into
+// the combined, deduplicated list of target names/patterns they stand for.
+func expandPresets(list string) []string {
+	var names []string
+	for _, preset := range strings.Split(list, ",") {
+		preset = strings.TrimSpace(preset)
+		if preset == "" {
+			continue
+		}
+		if preset == "all" {
+			for _, dirs := range presets {
+				names = append(names, dirs...)
+			}
+			continue
+		}
+		names = append(names, presets[preset]...)
+	}
+	return names
+}
+
+// buildTargetMatcher builds the matcher used to recognize a directory as a
+// deletion candidate. If a -preset is given and the user didn't also pass an
+// explicit -targets, the preset replaces the (node_modules,vendor) default
+// entirely, so e.g. -preset=python only ever matches Python's own leftover
+// directories. Passing both combines them, as an explicit opt-in.
+func buildTargetMatcher(targets string, targetsExplicit bool, preset string) matcher {
+	if preset != "" && !targetsExplicit {
+		m := matcher{names: map[string]bool{}}
+		for _, name := range expandPresets(preset) {
+			m.add(name)
+		}
+		return m
+	}
+
+	m := newMatcher(targets)
+	for _, name := range expandPresets(preset) {
+		m.add(name)
+	}
+	return m
+}
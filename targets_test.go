@@ -0,0 +1,90 @@
+package main
+
+import "testing"
+
+func TestMatcherExactName(t *testing.T) {
+	m := newMatcher("node_modules,vendor")
+	if !m.Match("node_modules") {
+		t.Error("expected node_modules to match")
+	}
+	if m.Match("node_modules_backup") {
+		t.Error("exact name matcher should not match a superstring")
+	}
+}
+
+func TestMatcherGlobPattern(t *testing.T) {
+	m := newMatcher("*.egg-info")
+	if !m.Match("foo.egg-info") {
+		t.Error("expected foo.egg-info to match *.egg-info")
+	}
+	if m.Match("egg-info") {
+		t.Error("*.egg-info should not match a name with no prefix")
+	}
+}
+
+func TestMatcherEmptyList(t *testing.T) {
+	m := newMatcher("")
+	if m.Match("anything") {
+		t.Error("empty matcher should match nothing")
+	}
+}
+
+func TestExpandPresetsSingle(t *testing.T) {
+	got := expandPresets("python")
+	want := map[string]bool{"__pycache__": true, ".venv": true, "venv": true, "*.egg-info": true}
+	if len(got) != len(want) {
+		t.Fatalf("expandPresets(python) = %v, want %d entries", got, len(want))
+	}
+	for _, g := range got {
+		if !want[g] {
+			t.Errorf("unexpected preset entry %q", g)
+		}
+	}
+}
+
+func TestExpandPresetsAllDedupes(t *testing.T) {
+	all := expandPresets("all")
+	seen := map[string]int{}
+	for _, name := range all {
+		seen[name]++
+	}
+	// "target" is shared by the rust and java presets; "all" should still
+	// include it once per preset definition, not collapse or duplicate it
+	// beyond what the preset table itself defines.
+	if seen["vendor"] != 1 {
+		t.Errorf("expected vendor to appear once via the go preset, got %d", seen["vendor"])
+	}
+	if seen["target"] != 2 {
+		t.Errorf("expected target to appear twice (rust + java presets), got %d", seen["target"])
+	}
+}
+
+func TestBuildTargetMatcherPresetReplacesDefault(t *testing.T) {
+	// -preset without an explicit -targets should NOT also match the
+	// node_modules/vendor default — only what the preset expands to.
+	m := buildTargetMatcher("node_modules,vendor", false, "python")
+	if m.Match("node_modules") {
+		t.Error("preset-only selection should not match the node_modules default")
+	}
+	if !m.Match("__pycache__") {
+		t.Error("preset-only selection should match its own preset's directories")
+	}
+}
+
+func TestBuildTargetMatcherExplicitTargetsCombinesWithPreset(t *testing.T) {
+	// An explicit -targets is an opt-in to combine with -preset.
+	m := buildTargetMatcher("node_modules,vendor", true, "python")
+	if !m.Match("node_modules") {
+		t.Error("explicit -targets should still match alongside the preset")
+	}
+	if !m.Match("__pycache__") {
+		t.Error("preset entries should still match when combined with explicit -targets")
+	}
+}
+
+func TestBuildTargetMatcherNoPresetUsesTargets(t *testing.T) {
+	m := buildTargetMatcher("node_modules,vendor", false, "")
+	if !m.Match("vendor") {
+		t.Error("expected default targets to apply with no preset set")
+	}
+}
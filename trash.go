@@ -0,0 +1,251 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+const manifestName = "manifest.json"
+
+// trashManifest records what a trashed entry was, so it can be listed or
+// restored later without remembering its quarantine path.
+type trashManifest struct {
+	ID           string    `json:"id"`
+	OriginalPath string    `json:"original_path"`
+	SizeBytes    int64     `json:"size_bytes"`
+	DeletedAt    time.Time `json:"deleted_at"`
+}
+
+// trashRoot returns the quarantine directory -trash moves matched
+// directories into, honoring $XDG_CACHE_HOME via os.UserCacheDir.
+func trashRoot() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cacheDir, "delPack", "trash"), nil
+}
+
+// moveToTrash relocates path into the quarantine area and writes a manifest
+// recording where it came from, returning the entry's id. If path and the
+// trash root live on different filesystems, os.Rename fails with EXDEV and
+// moveToTrash falls back to a recursive copy followed by os.RemoveAll.
+func moveToTrash(path string, size int64) (string, error) {
+	root, err := trashRoot()
+	if err != nil {
+		return "", err
+	}
+
+	id := trashID(path)
+	entryDir := filepath.Join(root, id)
+	if err := os.MkdirAll(entryDir, 0755); err != nil {
+		return "", err
+	}
+	payloadDir := filepath.Join(entryDir, "payload")
+
+	if err := os.Rename(path, payloadDir); err != nil {
+		// Rename fails with EXDEV when path and the trash root are on
+		// different filesystems; fall back to a copy followed by a remove.
+		if copyErr := copyTree(path, payloadDir); copyErr != nil {
+			return "", fmt.Errorf("move %s to trash: %w", path, copyErr)
+		}
+		if rmErr := os.RemoveAll(path); rmErr != nil {
+			return "", fmt.Errorf("remove %s after copying to trash: %w", path, rmErr)
+		}
+	}
+
+	m := trashManifest{ID: id, OriginalPath: path, SizeBytes: size, DeletedAt: time.Now()}
+	f, err := os.Create(filepath.Join(entryDir, manifestName))
+	if err != nil {
+		return id, err
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return id, enc.Encode(m)
+}
+
+// trashID derives a stable-looking, collision-resistant id for an entry from
+// the time it was trashed and a short hash of its original path.
+func trashID(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return fmt.Sprintf("%d-%s", time.Now().UnixNano(), hex.EncodeToString(sum[:])[:12])
+}
+
+// copyTree recursively copies src to dst, preserving file modes.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(p string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode())
+		}
+		return copyFile(p, target, info.Mode())
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// readTrashManifests loads every manifest under the trash root.
+func readTrashManifests() ([]trashManifest, error) {
+	root, err := trashRoot()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(root)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []trashManifest
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(root, e.Name(), manifestName))
+		if err != nil {
+			continue
+		}
+		var m trashManifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			continue
+		}
+		manifests = append(manifests, m)
+	}
+	sort.Slice(manifests, func(i, j int) bool { return manifests[i].DeletedAt.Before(manifests[j].DeletedAt) })
+	return manifests, nil
+}
+
+// cmdListTrash implements `delPack list-trash`.
+func cmdListTrash(args []string) {
+	fs := flag.NewFlagSet("list-trash", flag.ExitOnError)
+	fs.Parse(args)
+
+	manifests, err := readTrashManifests()
+	if err != nil {
+		log.Fatalf("❌ Could not read trash: %v", err)
+	}
+	if len(manifests) == 0 {
+		fmt.Println("🗑️  Trash is empty.")
+		return
+	}
+
+	fmt.Printf("%-28s %10s  %-20s  %s\n", "ID", "SIZE", "DELETED AT", "ORIGINAL PATH")
+	for _, m := range manifests {
+		fmt.Printf("%-28s %10s  %-20s  %s\n", m.ID, formatBytes(m.SizeBytes), m.DeletedAt.Format(time.RFC3339), m.OriginalPath)
+	}
+}
+
+// cmdRestore implements `delPack restore <id>`.
+func cmdRestore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		log.Fatal("❌ Usage: delPack restore <id>")
+	}
+	id := fs.Arg(0)
+
+	root, err := trashRoot()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	entryDir := filepath.Join(root, id)
+
+	data, err := os.ReadFile(filepath.Join(entryDir, manifestName))
+	if err != nil {
+		log.Fatalf("❌ Unknown trash id %q: %v", id, err)
+	}
+	var m trashManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		log.Fatalf("❌ Corrupt manifest for %q: %v", id, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(m.OriginalPath), 0755); err != nil {
+		log.Fatalf("❌ Could not recreate parent of %s: %v", m.OriginalPath, err)
+	}
+
+	payloadDir := filepath.Join(entryDir, "payload")
+	if err := os.Rename(payloadDir, m.OriginalPath); err != nil {
+		if copyErr := copyTree(payloadDir, m.OriginalPath); copyErr != nil {
+			log.Fatalf("❌ Could not restore %s: %v", m.OriginalPath, copyErr)
+		}
+	}
+	os.RemoveAll(entryDir)
+
+	fmt.Printf("✅ Restored %s to %s\n", id, m.OriginalPath)
+}
+
+// cmdEmptyTrash implements `delPack empty-trash --older-than=7d`.
+func cmdEmptyTrash(args []string) {
+	fs := flag.NewFlagSet("empty-trash", flag.ExitOnError)
+	olderThanFlag := fs.String("older-than", "0d", "Only remove trash entries older than this duration (e.g. 7d)")
+	fs.Parse(args)
+
+	olderThan, err := parseDuration(*olderThanFlag)
+	if err != nil {
+		log.Fatalf("❌ Invalid -older-than value: %v", err)
+	}
+
+	manifests, err := readTrashManifests()
+	if err != nil {
+		log.Fatalf("❌ Could not read trash: %v", err)
+	}
+
+	root, err := trashRoot()
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	var removed, freed int64
+	for _, m := range manifests {
+		if time.Since(m.DeletedAt) < olderThan {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, m.ID)); err != nil {
+			fmt.Printf("❌ Could not remove %s: %v\n", m.ID, err)
+			continue
+		}
+		removed++
+		freed += m.SizeBytes
+	}
+
+	fmt.Printf("✅ Removed %d trash entries, freeing %s\n", removed, formatBytes(freed))
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// selectItem wraps a foundDir with the interactive TUI's selection state.
+type selectItem struct {
+	dir      foundDir
+	selected bool
+}
+
+// sortMode controls how selectModel orders its items.
+type sortMode int
+
+const (
+	sortBySize sortMode = iota
+	sortByAge
+)
+
+// selectModel is a bubbletea model that lets the user check/uncheck
+// directories found during the scan, sort by size or age, and filter by a
+// substring, before confirming which ones to delete.
+type selectModel struct {
+	items     []selectItem
+	cursor    int
+	sortMode  sortMode
+	filter    string
+	editing   bool
+	quitting  bool
+	confirmed bool
+}
+
+func newSelectModel(found []foundDir) selectModel {
+	items := make([]selectItem, len(found))
+	for i, f := range found {
+		items[i] = selectItem{dir: f, selected: true}
+	}
+	m := selectModel{items: items}
+	m.sort()
+	return m
+}
+
+func (m *selectModel) sort() {
+	switch m.sortMode {
+	case sortBySize:
+		sort.SliceStable(m.items, func(i, j int) bool { return m.items[i].dir.size > m.items[j].dir.size })
+	case sortByAge:
+		sort.SliceStable(m.items, func(i, j int) bool { return m.items[i].dir.modTime.Before(m.items[j].dir.modTime) })
+	}
+}
+
+// visible returns the indices of items matching the current filter.
+func (m selectModel) visible() []int {
+	var idx []int
+	for i, it := range m.items {
+		if m.filter == "" || strings.Contains(strings.ToLower(it.dir.path), strings.ToLower(m.filter)) {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func (m selectModel) Init() tea.Cmd { return nil }
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if m.editing {
+		switch keyMsg.Type {
+		case tea.KeyEnter, tea.KeyEsc:
+			m.editing = false
+		case tea.KeyBackspace:
+			if len(m.filter) > 0 {
+				m.filter = m.filter[:len(m.filter)-1]
+			}
+		case tea.KeyRunes:
+			m.filter += string(keyMsg.Runes)
+		}
+		return m, nil
+	}
+
+	visible := m.visible()
+
+	switch keyMsg.String() {
+	case "q", "ctrl+c", "esc":
+		m.quitting = true
+		return m, tea.Quit
+	case "enter":
+		m.quitting = true
+		m.confirmed = true
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(visible)-1 {
+			m.cursor++
+		}
+	case " ":
+		if m.cursor < len(visible) {
+			i := visible[m.cursor]
+			m.items[i].selected = !m.items[i].selected
+		}
+	case "a":
+		for i := range m.items {
+			m.items[i].selected = true
+		}
+	case "n":
+		for i := range m.items {
+			m.items[i].selected = false
+		}
+	case "s":
+		m.sortMode = (m.sortMode + 1) % 2
+		m.sort()
+	case "/":
+		m.editing = true
+	}
+	return m, nil
+}
+
+func (m selectModel) View() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "delPack — space: toggle, a: all, n: none, s: sort (%s), /: filter, enter: confirm, q: cancel\n", sortLabel(m.sortMode))
+	if m.filter != "" || m.editing {
+		fmt.Fprintf(&b, "filter: %s\n", m.filter)
+	}
+	for i, idx := range m.visible() {
+		it := m.items[idx]
+		box := "[ ]"
+		if it.selected {
+			box = "[x]"
+		}
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "> "
+		}
+		fmt.Fprintf(&b, "%s%s %-60s %10s  %s\n", cursor, box, it.dir.path, formatBytes(it.dir.size), it.dir.modTime.Format("2006-01-02"))
+	}
+	return b.String()
+}
+
+func sortLabel(s sortMode) string {
+	if s == sortByAge {
+		return "age"
+	}
+	return "size"
+}
+
+// runInteractiveSelect shows the TUI and returns the directories the user
+// left checked when they confirmed with enter. It returns ok=false if the
+// user cancelled instead.
+func runInteractiveSelect(found []foundDir) (selected []foundDir, ok bool, err error) {
+	p := tea.NewProgram(newSelectModel(found))
+	result, err := p.Run()
+	if err != nil {
+		return nil, false, err
+	}
+
+	m := result.(selectModel)
+	if !m.confirmed {
+		return nil, false, nil
+	}
+	for _, it := range m.items {
+		if it.selected {
+			selected = append(selected, it.dir)
+		}
+	}
+	return selected, true, nil
+}